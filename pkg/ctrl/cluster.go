@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/kubebuilder/pkg/client"
+	"github.com/kubernetes-sigs/kubebuilder/pkg/informer"
+	"k8s.io/client-go/rest"
+)
+
+// Cluster holds the dependencies a Controller needs in order to watch and act on a single
+// Kubernetes cluster other than the one ControllerManager.Config points at.
+type Cluster struct {
+	// Name identifies the Cluster and is the key Controllers use to look up its Informers, Config and
+	// Client via inject.InjectClusterInformers, inject.InjectClusterConfigs and inject.InjectClusterClients.
+	Name string
+
+	// Config is the rest.Config used to talk to this Cluster's apiserver.
+	Config *rest.Config
+
+	// informers is the Informers for this Cluster.
+	informers informer.Informers
+
+	// client is the client.Interface for this Cluster, used by Controllers to act on the objects they watch.
+	client client.Interface
+}
+
+// ClusterSet is the set of additional Clusters registered with a ControllerManager, keyed by name.
+type ClusterSet map[string]*Cluster
+
+// informers returns the per-cluster Informers in this ClusterSet, keyed by cluster name.
+func (cs ClusterSet) informers() map[string]informer.Informers {
+	m := make(map[string]informer.Informers, len(cs))
+	for name, c := range cs {
+		m[name] = c.informers
+	}
+	return m
+}
+
+// configs returns the per-cluster rest.Config in this ClusterSet, keyed by cluster name.
+func (cs ClusterSet) configs() map[string]*rest.Config {
+	m := make(map[string]*rest.Config, len(cs))
+	for name, c := range cs {
+		m[name] = c.Config
+	}
+	return m
+}
+
+// clients returns the per-cluster client.Interface in this ClusterSet, keyed by cluster name.
+func (cs ClusterSet) clients() map[string]client.Interface {
+	m := make(map[string]client.Interface, len(cs))
+	for name, c := range cs {
+		m[name] = c.client
+	}
+	return m
+}
+
+// AddCluster registers a named Cluster with the ControllerManager.  Controllers that declare interest
+// in this cluster name via inject.InjectClusterInformers or inject.InjectClusterConfigs will have this
+// Cluster's Informers and Config injected at Start time, in addition to the ControllerManager's own.
+// AddCluster must be called before Start.
+func (cm *ControllerManager) AddCluster(name string, cfg *rest.Config) error {
+	cm.init()
+	if cm.err != nil {
+		return cm.err
+	}
+	if _, found := cm.clusters[name]; found {
+		return fmt.Errorf("cluster %q already registered with this ControllerManager", name)
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: cm.Scheme})
+	if err != nil {
+		return err
+	}
+
+	if cm.clusters == nil {
+		cm.clusters = ClusterSet{}
+	}
+	cm.clusters[name] = &Cluster{
+		Name:   name,
+		Config: cfg,
+		informers: &informer.SelfPopulatingInformers{
+			Config: cfg,
+			Scheme: cm.Scheme,
+		},
+		client: c,
+	}
+	return nil
+}
+
+// startClusters starts the Informers for every registered Cluster.  Informers.Start does not itself
+// return an error, so unlike Controllers there is nothing to propagate here yet; this just fans the
+// call out across every registered Cluster.
+func (cm *ControllerManager) startClusters(stop <-chan struct{}) {
+	for _, c := range cm.clusters {
+		c.informers.Start(stop)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/kubebuilder/pkg/client"
+	"github.com/kubernetes-sigs/kubebuilder/pkg/ctrl"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/testing_frameworks/integration"
+)
+
+// TestCrossClusterReconciliation starts two envtest control planes, registers both as Clusters on a
+// ControllerManager, and verifies a Controller gets both Clusters' clients injected (via
+// inject.InjectClusterClients, reachable off the Controller itself as ClusterClients) so it can read an
+// object created in cluster "a" and mirror it into cluster "b".
+func TestCrossClusterReconciliation(t *testing.T) {
+	clusterA := &integration.ControlPlane{}
+	clusterB := &integration.ControlPlane{}
+	if err := clusterA.Start(); err != nil {
+		t.Fatalf("starting cluster a: %v", err)
+	}
+	defer clusterA.Stop()
+	if err := clusterB.Start(); err != nil {
+		t.Fatalf("starting cluster b: %v", err)
+	}
+	defer clusterB.Stop()
+
+	cm := &ctrl.ControllerManager{Config: clusterA.RESTConfig()}
+	if err := cm.AddCluster("a", clusterA.RESTConfig()); err != nil {
+		t.Fatalf("AddCluster(a): %v", err)
+	}
+	if err := cm.AddCluster("b", clusterB.RESTConfig()); err != nil {
+		t.Fatalf("AddCluster(b): %v", err)
+	}
+
+	mirrored := make(chan string, 1)
+	c := &ctrl.Controller{Name: "mirror"}
+	c.Reconcile = func(req ctrl.ReconcileRequest) (ctrl.ReconcileResult, error) {
+		src := c.ClusterClients["a"]
+		dst := c.ClusterClients["b"]
+
+		pod := &v1.Pod{}
+		if err := src.Get(req.NamespacedName, pod); err != nil {
+			return ctrl.ReconcileResult{}, err
+		}
+		mirror := pod.DeepCopy()
+		mirror.ResourceVersion = ""
+		if err := dst.Create(mirror); err != nil {
+			return ctrl.ReconcileResult{}, err
+		}
+		mirrored <- pod.Name
+		return ctrl.ReconcileResult{}, nil
+	}
+	cm.AddController(c, func() {
+		c.Watch(&v1.Pod{})
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go cm.Start(stop)
+
+	clientA, err := client.New(clusterA.RESTConfig(), client.Options{})
+	if err != nil {
+		t.Fatalf("building client for cluster a: %v", err)
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "source-pod", Namespace: "default"}}
+	if err := clientA.Create(pod); err != nil {
+		t.Fatalf("creating pod in cluster a: %v", err)
+	}
+
+	select {
+	case name := <-mirrored:
+		if name != "source-pod" {
+			t.Fatalf("mirrored unexpected pod %q", name)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for cross-cluster reconciliation")
+	}
+}
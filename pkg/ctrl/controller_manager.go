@@ -18,14 +18,17 @@ package ctrl
 
 import (
 	"sync"
+	"time"
 
 	"github.com/kubernetes-sigs/kubebuilder/pkg/client"
 	"github.com/kubernetes-sigs/kubebuilder/pkg/config"
 	"github.com/kubernetes-sigs/kubebuilder/pkg/ctrl/inject"
 	"github.com/kubernetes-sigs/kubebuilder/pkg/informer"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 )
 
 // DefaultControllerManager is the default ControllerManager.
@@ -49,6 +52,46 @@ type ControllerManager struct {
 	// TODO(directxman12): Provide an escape hatch to get individual indexers
 	client client.Interface
 
+	// clusters holds the additional Clusters registered via AddCluster, keyed by name.
+	clusters ClusterSet
+
+	// LeaderElection configures whether this ControllerManager must acquire a lock before starting
+	// its Controllers and Informers.  Leave the zero value to run without leader election.
+	LeaderElection LeaderElection
+
+	// ShutdownTimeout bounds how long Start waits for Controller goroutines to return once stop is
+	// closed, before giving up and returning an error.  Defaults to 30 seconds if zero.
+	ShutdownTimeout time.Duration
+
+	// HealthProbeBindAddress is the address the healthz/readyz HTTP server binds to, e.g. ":8081".
+	// Leave empty to disable the probe server.
+	HealthProbeBindAddress string
+
+	// MetricsBindAddress is the address the Prometheus /metrics HTTP server binds to, e.g. ":8080".
+	// Leave empty to disable the metrics server.
+	MetricsBindAddress string
+
+	// Registry is the prometheus.Registerer Controllers register their metrics with.  Defaults to a
+	// fresh prometheus.Registry if nil.  Set this to add custom collectors alongside the default ones.
+	Registry *prometheus.Registry
+
+	// healthzChecks are the checks registered via AddHealthzCheck.
+	healthzChecks []namedCheck
+
+	// readyzChecks are the checks registered via AddReadyzCheck.
+	readyzChecks []namedCheck
+
+	// eventBroadcaster is lazily created by GetEventRecorderFor.
+	eventBroadcaster record.EventBroadcaster
+
+	// eventBroadcasterOnce guards lazy creation of eventBroadcaster.
+	eventBroadcasterOnce sync.Once
+
+	// eventBroadcasterErr is set if eventBroadcaster could not be wired up to record events to the
+	// apiserver.  Events are optional, so this degrades to log-only instead of failing Start; it is not
+	// exposed outside this package.
+	eventBroadcasterErr error
+
 	// once ensures unspecified fields get default values
 	once sync.Once
 
@@ -72,19 +115,45 @@ func (cm *ControllerManager) AddController(c *Controller, promise func()) {
 // Start starts all registered Controllers and blocks until the Stop channel is closed.
 // Returns an error if there is an error starting any Controller.
 // Injects Informers and Config into Controllers before Starting them.
+// If LeaderElection is enabled, Controllers remain inert until this ControllerManager acquires the lock.
 func (cm *ControllerManager) Start(stop <-chan struct{}) error {
 	cm.init()
 	if cm.err != nil {
 		return cm.err
 	}
 
+	// Bind the probe and metrics servers regardless of leader election, so that standby replicas still
+	// answer liveness/readiness probes and aren't restart-looped while waiting to acquire the lock.
+	if err := cm.startHealthProbes(stop); err != nil {
+		return err
+	}
+	if err := cm.startMetricsServer(stop); err != nil {
+		return err
+	}
+
+	if cm.LeaderElection.Enabled {
+		return cm.startWithLeaderElection(stop)
+	}
+	return cm.startControllers(stop)
+}
+
+// startControllers injects dependencies into and starts all registered Controllers and Clusters, and
+// blocks until stop is closed or one of them returns an error.
+func (cm *ControllerManager) startControllers(stop <-chan struct{}) error {
 	// Inject into each of the controllers
 	for _, c := range cm.controllers {
 		inject.InjectInformers(cm.informers, c)
 		inject.InjectConfig(cm.Config, c)
+		inject.InjectMetrics(cm.Registry, c)
+		inject.InjectRecorder(cm.GetEventRecorderFor(c.Name), c)
+		if len(cm.clusters) > 0 {
+			inject.InjectClusterInformers(cm.clusters.informers(), c)
+			inject.InjectClusterConfigs(cm.clusters.configs(), c)
+			inject.InjectClusterClients(cm.clusters.clients(), c)
+		}
 	}
 
-	// Run the promises that may add Watches to the informers
+	// Run the promises that may add Watches to the informers, including the per-cluster ones
 	for _, p := range cm.promises {
 		p()
 	}
@@ -92,20 +161,32 @@ func (cm *ControllerManager) Start(stop <-chan struct{}) error {
 	// Start the informers now that watches have been added
 
 	cm.informers.Start(stop)
+	cm.startClusters(stop)
+	cm.stopEventBroadcaster(stop)
 
 	// Start the controllers after the promises
-	controllerErrors := make(chan error)
+	var wg sync.WaitGroup
+	controllerErrors := make(chan error, len(cm.controllers))
 	for _, c := range cm.controllers {
 		// Controllers block, but we want to return an error if any have an error starting.
 		// Write any Start errors to a channel so we can return them
-		go func() {
+		wg.Add(1)
+		go func(c *Controller) {
+			defer wg.Done()
 			controllerErrors <- c.Start(stop)
-		}()
+		}(c)
 	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
 	select {
 	case <-stop:
-		// We are done
-		return nil
+		// Wait for the controller goroutines to actually return before unblocking, instead of
+		// leaking their in-flight reconciles and racing the informers.
+		return cm.waitForShutdown(done, controllerErrors)
 	case err := <-controllerErrors:
 		// Error starting a controller
 		return err
@@ -129,6 +210,10 @@ func (cm *ControllerManager) init() {
 				Scheme: cm.Scheme,
 			}
 		}
+
+		if cm.Registry == nil {
+			cm.Registry = prometheus.NewRegistry()
+		}
 	})
 }
 
@@ -0,0 +1,69 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+)
+
+// GetEventRecorderFor returns a record.EventRecorder that emits events under the given component name.
+// The first call lazily creates the ControllerManager's EventBroadcaster, bound to Config's corev1
+// Events client and logging through klog; later calls reuse it.  The broadcaster is shut down when the
+// stop channel passed to Start is closed.
+//
+// If the apiserver client used to record events to the sink can't be built, the recorder degrades to
+// logging events through klog only; events are optional, so this does not fail Start.  The failure is
+// logged and kept in the unexported eventBroadcasterErr field; no exported method returns it.
+func (cm *ControllerManager) GetEventRecorderFor(name string) record.EventRecorder {
+	cm.init()
+	cm.eventBroadcasterOnce.Do(func() {
+		broadcaster := record.NewBroadcaster()
+		broadcaster.StartLogging(klog.Infof)
+		clientset, err := kubernetes.NewForConfig(cm.Config)
+		if err != nil {
+			cm.eventBroadcasterErr = fmt.Errorf("could not build client for event sink: %v", err)
+			klog.Errorf("%v; events will only be logged, not recorded to the apiserver", cm.eventBroadcasterErr)
+		} else {
+			broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+		}
+		cm.eventBroadcaster = broadcaster
+	})
+	return cm.eventBroadcaster.NewRecorder(cm.Scheme, corev1.EventSource{Component: name})
+}
+
+// stopEventBroadcaster shuts down the EventBroadcaster, if one was ever created, once stop is closed.
+// The Shutdown method isn't part of every vintage of the record.EventBroadcaster interface, so it's
+// invoked through a runtime assertion rather than called directly.
+func (cm *ControllerManager) stopEventBroadcaster(stop <-chan struct{}) {
+	if cm.eventBroadcaster == nil {
+		return
+	}
+	shutdowner, ok := cm.eventBroadcaster.(interface{ Shutdown() })
+	if !ok {
+		return
+	}
+	go func() {
+		<-stop
+		shutdowner.Shutdown()
+	}()
+}
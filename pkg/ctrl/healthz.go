@@ -0,0 +1,104 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"k8s.io/klog"
+)
+
+// namedCheck pairs a health or readiness check with the name it is reported under.
+type namedCheck struct {
+	name  string
+	check func() error
+}
+
+// AddHealthzCheck registers a named check that must pass for /healthz to report healthy.
+// AddHealthzCheck must be called before Start.
+func (cm *ControllerManager) AddHealthzCheck(name string, check func() error) {
+	cm.healthzChecks = append(cm.healthzChecks, namedCheck{name: name, check: check})
+}
+
+// AddReadyzCheck registers a named check that must pass for /readyz to report ready.
+// AddReadyzCheck must be called before Start.
+func (cm *ControllerManager) AddReadyzCheck(name string, check func() error) {
+	cm.readyzChecks = append(cm.readyzChecks, namedCheck{name: name, check: check})
+}
+
+// startHealthProbes serves /healthz and /readyz on HealthProbeBindAddress until stop is closed.
+// Readiness is automatically tied to HasSynced for every Informer managed by this ControllerManager.
+func (cm *ControllerManager) startHealthProbes(stop <-chan struct{}) error {
+	if cm.HealthProbeBindAddress == "" {
+		return nil
+	}
+
+	readyzChecks := append([]namedCheck{{name: "informers", check: cm.informersSynced}}, cm.readyzChecks...)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", probeHandler(cm.healthzChecks))
+	mux.HandleFunc("/readyz", probeHandler(readyzChecks))
+
+	ln, err := net.Listen("tcp", cm.HealthProbeBindAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			// The probe server isn't consulted again once Start has returned, so just log it.
+			klog.Errorf("healthz/readyz server exited: %v", err)
+		}
+	}()
+	go func() {
+		<-stop
+		server.Close()
+	}()
+
+	return nil
+}
+
+// informersSynced reports an error unless every Informer managed by this ControllerManager, including
+// those belonging to registered Clusters, has synced.
+func (cm *ControllerManager) informersSynced() error {
+	if cm.informers != nil && !cm.informers.HasSynced() {
+		return fmt.Errorf("informers not synced")
+	}
+	for name, c := range cm.clusters {
+		if !c.informers.HasSynced() {
+			return fmt.Errorf("informers for cluster %q not synced", name)
+		}
+	}
+	return nil
+}
+
+// probeHandler runs every check and responds 200 if all pass, or 500 naming the first failure.
+func probeHandler(checks []namedCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, c := range checks {
+			if err := c.check(); err != nil {
+				http.Error(w, fmt.Sprintf("%s: %v", c.name, err), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "ok")
+	}
+}
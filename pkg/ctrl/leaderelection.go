@@ -0,0 +1,147 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaderElection configures leader-election for a ControllerManager, so that multiple replicas of the
+// same controller binary can run for HA while only the elected leader actively reconciles.
+type LeaderElection struct {
+	// Enabled turns on leader election.  When false, all other fields are ignored and Start runs
+	// Controllers immediately.
+	Enabled bool
+
+	// LockName is the name of the resourcelock object used to record the current leader.
+	LockName string
+
+	// LockNamespace is the namespace of the resourcelock object.
+	LockNamespace string
+
+	// ResourceLock is the type of resourcelock to use: "configmaps", "leases", or "endpoints".
+	// Defaults to "configmaps" if empty.
+	ResourceLock string
+
+	// LeaseDuration is the duration non-leader candidates will wait before forcing acquisition.
+	// Defaults to 15 seconds if zero.
+	LeaseDuration time.Duration
+
+	// RenewDeadline is the duration the leader will retry refreshing leadership before giving it up.
+	// Defaults to 10 seconds if zero.
+	RenewDeadline time.Duration
+
+	// RetryPeriod is how often the leader elector clients should try to act on the lock.
+	// Defaults to 2 seconds if zero.
+	RetryPeriod time.Duration
+
+	// OnStoppedLeading is called when this ControllerManager loses leadership, so users can trigger a
+	// graceful shutdown of their binary.  If nil, losing leadership simply causes Start to return an error.
+	OnStoppedLeading func()
+}
+
+// startWithLeaderElection acquires the configured leader-election lock and only then calls
+// startControllers, with the Controllers' stop tied to leadership: losing the lock stops them just as
+// surely as the outer stop does.  It returns an error if the lock cannot be acquired, or once leadership
+// is lost.
+func (cm *ControllerManager) startWithLeaderElection(stop <-chan struct{}) error {
+	id, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("could not determine leader election identity: %v", err)
+	}
+
+	client, err := kubernetes.NewForConfig(cm.Config)
+	if err != nil {
+		return err
+	}
+
+	resourceLock := cm.LeaderElection.ResourceLock
+	if resourceLock == "" {
+		resourceLock = resourcelock.ConfigMapsResourceLock
+	}
+	lock, err := resourcelock.New(resourceLock,
+		cm.LeaderElection.LockNamespace,
+		cm.LeaderElection.LockName,
+		client.CoreV1(),
+		client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: id},
+	)
+	if err != nil {
+		return err
+	}
+
+	leaseDuration := cm.LeaderElection.LeaseDuration
+	if leaseDuration == 0 {
+		leaseDuration = 15 * time.Second
+	}
+	renewDeadline := cm.LeaderElection.RenewDeadline
+	if renewDeadline == 0 {
+		renewDeadline = 10 * time.Second
+	}
+	retryPeriod := cm.LeaderElection.RetryPeriod
+	if retryPeriod == 0 {
+		retryPeriod = 2 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	result := make(chan error, 1)
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				// Tie the Controllers' stop to leadership, not just the outer stop, so losing the
+				// lock makes them inert again instead of continuing to reconcile without it.
+				controllerStop := make(chan struct{})
+				go func() {
+					select {
+					case <-stop:
+					case <-leaderCtx.Done():
+					}
+					close(controllerStop)
+				}()
+				result <- cm.startControllers(controllerStop)
+			},
+			OnStoppedLeading: func() {
+				if cm.LeaderElection.OnStoppedLeading != nil {
+					cm.LeaderElection.OnStoppedLeading()
+				}
+				select {
+				case result <- fmt.Errorf("leader election lost for lock %s/%s", cm.LeaderElection.LockNamespace, cm.LeaderElection.LockName):
+				default:
+				}
+			},
+		},
+	})
+
+	return <-result
+}
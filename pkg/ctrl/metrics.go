@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/klog"
+)
+
+// startMetricsServer serves Registry on MetricsBindAddress until stop is closed.  It is started before
+// the Controllers; once they are started, each registered Controller has its work-queue and Reconcile
+// loop wrapped with Registry's collectors via inject.InjectMetrics, so the default metrics (queue depth,
+// adds, retries, latency, unfinished work seconds, and reconcile count/duration labeled by result) show
+// up here without further setup.
+func (cm *ControllerManager) startMetricsServer(stop <-chan struct{}) error {
+	if cm.MetricsBindAddress == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(cm.Registry, promhttp.HandlerOpts{}))
+
+	ln, err := net.Listen("tcp", cm.MetricsBindAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			// The metrics server isn't consulted again once Start has returned, so just log it.
+			klog.Errorf("metrics server exited: %v", err)
+		}
+	}()
+	go func() {
+		<-stop
+		server.Close()
+	}()
+
+	return nil
+}
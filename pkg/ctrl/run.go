@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctrl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Run starts the ControllerManager and blocks until ctx is done, a SIGINT or SIGTERM is received, or a
+// Controller returns an error.  Run closes the stop channel it passes to Start on SIGINT, SIGTERM or
+// ctx being done, and Start then waits (bounded by ShutdownTimeout) for all Controller goroutines to
+// return before unblocking, so callers don't need to reimplement signal handling in their own main().
+func (cm *ControllerManager) Run(ctx context.Context) error {
+	stop := make(chan struct{})
+
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	go func() {
+		select {
+		case <-sigCh:
+		case <-ctx.Done():
+		}
+		close(stop)
+	}()
+
+	return cm.Start(stop)
+}
+
+// Run starts the DefaultControllerManager.  See ControllerManager.Run.
+func Run(ctx context.Context) error { return DefaultControllerManager.Run(ctx) }
+
+// waitForShutdown drains controllerErrors until done is closed, bounded by ShutdownTimeout, and joins
+// any non-nil errors collected along the way.
+func (cm *ControllerManager) waitForShutdown(done <-chan struct{}, controllerErrors <-chan error) error {
+	timeout := cm.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var errs []error
+	for {
+		select {
+		case err := <-controllerErrors:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-done:
+			// Every goroutine has sent its result by now; collect any still buffered.
+			for {
+				select {
+				case err := <-controllerErrors:
+					if err != nil {
+						errs = append(errs, err)
+					}
+				default:
+					return joinErrors(errs)
+				}
+			}
+		case <-timer.C:
+			errs = append(errs, fmt.Errorf("timed out after %s waiting for controllers to stop", timeout))
+			return joinErrors(errs)
+		}
+	}
+}
+
+// joinErrors combines multiple errors into one, or returns nil if errs is empty.
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	var buf bytes.Buffer
+	for i, err := range errs {
+		if i > 0 {
+			buf.WriteString("; ")
+		}
+		buf.WriteString(err.Error())
+	}
+	return fmt.Errorf("%s", buf.String())
+}